@@ -0,0 +1,71 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdmissionMiddlewareZeroMaxQueuedRequestsIsUnbounded(t *testing.T) {
+	mw := NewAdmissionMiddleware("test_zero_queue", AdmissionConfig{
+		MaxConcurrentRequests: 1,
+		MaxQueuedRequests:     0,
+		MaxQueueTime:          time.Second,
+	})
+
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with an idle pool and MaxQueuedRequests unset, got %d", rec.Code)
+	}
+}
+
+func TestAdmissionMiddlewareRejectsOverQueueBound(t *testing.T) {
+	release := make(chan struct{})
+	mw := NewAdmissionMiddleware("test_queue_bound", AdmissionConfig{
+		MaxConcurrentRequests: 1,
+		MaxQueuedRequests:     1,
+		MaxQueueTime:          time.Second,
+	})
+
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var wg sync.WaitGroup
+	codes := make([]int, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			codes[i] = rec.Code
+		}(i)
+	}
+
+	// Give all three goroutines time to reach the middleware: one takes
+	// the concurrency slot, one takes the single queue slot, and the
+	// third should be rejected immediately since the queue is full.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	var rejected int
+	for _, code := range codes {
+		if code == http.StatusServiceUnavailable {
+			rejected++
+		}
+	}
+	if rejected != 1 {
+		t.Fatalf("expected exactly 1 request rejected with 503 (queue bound 1), got %d of %v", rejected, codes)
+	}
+}
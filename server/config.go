@@ -0,0 +1,58 @@
+package server
+
+import "time"
+
+// Config is the configuration for the server subsystem, covering every
+// transport (HTTP, gRPC) that a Server/GRPCServer pair can expose.
+type Config struct {
+	// HTTP is the configuration for the HTTP server.
+	HTTP HTTPConfig
+	// GRPC is the configuration for the gRPC server.
+	GRPC GRPCConfig
+}
+
+// HTTPConfig is the configuration for the HTTP server.
+type HTTPConfig struct {
+	// Host is the host the HTTP server listens on.
+	Host string
+	// Port is the port the HTTP server listens on.
+	Port int
+	// PrometheusPrefix is the prefix for the admission-control metrics
+	// emitted for this server.
+	PrometheusPrefix string
+	// AdmissionConfig bounds concurrency for the server as a whole. A
+	// zero value (MaxConcurrentRequests == 0) disables admission control.
+	AdmissionConfig
+	// RouteOverrides gives individual routes (keyed by Route.Path) their
+	// own AdmissionConfig, applied inside the server-wide one rather than
+	// in place of it.
+	RouteOverrides map[string]AdmissionConfig
+}
+
+// AdmissionConfig bounds how many requests a server or a single route may
+// have in flight at once.
+type AdmissionConfig struct {
+	// MaxConcurrentRequests is the maximum number of requests allowed to
+	// execute at the same time.
+	MaxConcurrentRequests int
+	// MaxQueuedRequests is the maximum number of requests allowed to wait
+	// for a free concurrency slot before new requests are rejected
+	// outright.
+	MaxQueuedRequests int
+	// MaxQueueTime is the longest a request will wait for a free
+	// concurrency slot before being rejected with 503.
+	MaxQueueTime time.Duration
+}
+
+// GRPCConfig is the configuration for the gRPC server.
+type GRPCConfig struct {
+	// Host is the host the gRPC server listens on.
+	Host string
+	// Port is the port the gRPC server listens on. Ignored when MuxHTTP
+	// is set, since the gRPC server shares the HTTP server's listener.
+	Port int
+	// MuxHTTP, when true, multiplexes gRPC and HTTP/JSON traffic on the
+	// HTTP server's single listener (via cmux/h2c) instead of opening a
+	// dedicated gRPC listener on Host:Port.
+	MuxHTTP bool
+}
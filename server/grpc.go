@@ -0,0 +1,243 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// ServiceRegistrar is implemented by generated `_grpc.pb.go` RegisterXServer
+// functions and is what callers use to register services with a GRPCServer,
+// mirroring Server.RegisterHandler for the gRPC transport.
+type ServiceRegistrar = grpc.ServiceRegistrar
+
+// GRPCServer represents a gRPC server with configurable interceptors and
+// services. It shares the Start/Stop lifecycle conventions of Server and,
+// when Config.GRPC.MuxHTTP is set, can be served from the same listener
+// as an HTTP Server via h2c/cmux.
+type GRPCServer struct {
+	cfg    *Config
+	logger log.Logger
+	srv    *grpc.Server
+	closer sync.Once
+
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	registrars         []func(grpc.ServiceRegistrar)
+}
+
+// NewGRPC creates and returns a new GRPCServer instance. Middlewares passed
+// in are translated into gRPC interceptors via MiddlewareToUnaryInterceptor,
+// so the same Middleware values used for the HTTP Server can be reused here.
+func NewGRPC(cfg *Config, logger log.Logger, middlewares ...Middleware) *GRPCServer {
+	g := &GRPCServer{
+		cfg:    cfg,
+		logger: logger,
+	}
+	for _, m := range middlewares {
+		g.unaryInterceptors = append(g.unaryInterceptors, MiddlewareToUnaryInterceptor(m))
+		g.streamInterceptors = append(g.streamInterceptors, MiddlewareToStreamInterceptor(m))
+	}
+	return g
+}
+
+// RegisterService registers a gRPC service with the server. register is
+// typically a generated RegisterXServer function, e.g.:
+//
+//	g.RegisterService(func(r grpc.ServiceRegistrar) { pb.RegisterFooServer(r, impl) })
+func (g *GRPCServer) RegisterService(register func(grpc.ServiceRegistrar)) {
+	g.registrars = append(g.registrars, register)
+}
+
+// RegisterInterceptor adds a unary and stream interceptor pair to the
+// server, mirroring Server.RegisterMiddleware for the gRPC transport.
+func (g *GRPCServer) RegisterInterceptor(m Middleware) {
+	g.unaryInterceptors = append(g.unaryInterceptors, MiddlewareToUnaryInterceptor(m))
+	g.streamInterceptors = append(g.streamInterceptors, MiddlewareToStreamInterceptor(m))
+}
+
+// buildServer constructs the underlying grpc.Server and applies every
+// registered service, deferred until Start so interceptors/services
+// registered after NewGRPC are still picked up.
+func (g *GRPCServer) buildServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(g.unaryInterceptors...),
+		grpc.ChainStreamInterceptor(g.streamInterceptors...),
+	)
+	for _, register := range g.registrars {
+		register(srv)
+	}
+	return srv
+}
+
+// Start initializes and starts the gRPC server. This method is blocking
+// and should be run in a separate goroutine.
+//
+// If Config.GRPC.MuxHTTP is set, lis must be a listener not already being
+// served by anything else (typically created with net.Listen and handed
+// to Start instead of Server.Start/Serve) and httpHandler — typically the
+// paired Server's Handler() — serves every non-gRPC request multiplexed
+// onto that same listener via cmux/h2c. Otherwise lis and httpHandler are
+// both ignored, and Start listens on Config.GRPC.Host:Port itself, so a plain
+// HTTP Server can keep using Start/Serve on its own listener as usual.
+func (g *GRPCServer) Start(ctx context.Context, lis net.Listener, httpHandler http.Handler) error {
+	g.srv = g.buildServer()
+
+	if g.cfg.GRPC.MuxHTTP {
+		return g.startMuxed(ctx, lis, httpHandler)
+	}
+
+	addr := fmt.Sprintf("%s:%d", g.cfg.GRPC.Host, g.cfg.GRPC.Port)
+	grpcLis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	go func() {
+		g.logger.Info("Starting gRPC server", "address", addr)
+		if serveErr := g.srv.Serve(grpcLis); serveErr != nil && !errors.Is(serveErr, grpc.ErrServerStopped) {
+			g.logger.Error("gRPC server error", "error", serveErr)
+		}
+	}()
+
+	<-ctx.Done()
+	g.Stop()
+	return nil
+}
+
+// startMuxed multiplexes gRPC (HTTP/2) and plain HTTP/JSON traffic on a
+// single listener using cmux, serving gRPC directly and handing everything
+// else to httpHandler (the real application routes, not a stub) via h2c.
+func (g *GRPCServer) startMuxed(ctx context.Context, lis net.Listener, httpHandler http.Handler) error {
+	if lis == nil {
+		return errors.New("grpc: MuxHTTP requires a shared listener, got nil")
+	}
+	if httpHandler == nil {
+		return errors.New("grpc: MuxHTTP requires an httpHandler to serve non-gRPC requests, got nil")
+	}
+
+	m := cmux.New(lis)
+	grpcLis := m.MatchWithWriters(
+		cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"),
+	)
+	httpLis := m.Match(cmux.Any())
+
+	go func() {
+		if err := g.srv.Serve(grpcLis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			g.logger.Error("gRPC server error", "error", err)
+		}
+	}()
+	go func() {
+		if err := http.Serve(httpLis, h2c.NewHandler(httpHandler, &http2.Server{})); err != nil &&
+			!errors.Is(err, cmux.ErrListenerClosed) {
+			g.logger.Error("muxed HTTP server error", "error", err)
+		}
+	}()
+	go func() {
+		if err := m.Serve(); err != nil && !errors.Is(err, cmux.ErrListenerClosed) {
+			g.logger.Error("cmux server error", "error", err)
+		}
+	}()
+
+	<-ctx.Done()
+	g.Stop()
+	return nil
+}
+
+// Stop gracefully shuts down the gRPC server.
+func (g *GRPCServer) Stop() {
+	g.closer.Do(func() {
+		stopped := make(chan struct{})
+		go func() {
+			g.srv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			g.logger.Info("gRPC server gracefully stopped")
+		case <-time.After(5 * time.Second): //nolint:gomnd // matches Server.Stop's shutdown grace period.
+			g.logger.Error("gRPC server graceful stop timed out, forcing stop")
+			g.srv.Stop()
+		}
+	})
+}
+
+// MiddlewareToUnaryInterceptor adapts a Middleware to a
+// grpc.UnaryServerInterceptor so HTTP and gRPC transports can share the
+// same middleware implementations.
+func MiddlewareToUnaryInterceptor(m Middleware) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		var resp any
+		var handlerErr error
+
+		wrapped := m(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			resp, handlerErr = handler(ctx, req)
+		}))
+		wrapped.ServeHTTP(newNoopResponseWriter(), (&http.Request{}).WithContext(ctx))
+		return resp, handlerErr
+	}
+}
+
+// MiddlewareToStreamInterceptor adapts a Middleware to a
+// grpc.StreamServerInterceptor so HTTP and gRPC transports can share the
+// same middleware implementations.
+func MiddlewareToStreamInterceptor(m Middleware) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		var handlerErr error
+
+		wrapped := m(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			handlerErr = handler(srv, ss)
+		}))
+		wrapped.ServeHTTP(newNoopResponseWriter(), (&http.Request{}).WithContext(ss.Context()))
+		return handlerErr
+	}
+}
+
+// noopResponseWriter is a minimal, real http.ResponseWriter used to adapt
+// Middleware — written against real HTTP responses — to gRPC
+// interceptors, which have no http.ResponseWriter of their own. Unlike a
+// nil interface, it's safe for a middleware to call Header()/Write()/
+// WriteHeader() on; whatever it writes is simply discarded once the
+// interceptor returns.
+type noopResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// newNoopResponseWriter returns a ready-to-use noopResponseWriter.
+func newNoopResponseWriter() *noopResponseWriter {
+	return &noopResponseWriter{header: make(http.Header)}
+}
+
+// Header implements http.ResponseWriter.
+func (w *noopResponseWriter) Header() http.Header { return w.header }
+
+// Write implements http.ResponseWriter.
+func (w *noopResponseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+// WriteHeader implements http.ResponseWriter.
+func (w *noopResponseWriter) WriteHeader(status int) { w.status = status }
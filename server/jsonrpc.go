@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// jsonRPCVersion is the only JSON-RPC version RegisterJSONRPC supports.
+const jsonRPCVersion = "2.0"
+
+// jsonRPCRequest is a JSON-RPC 2.0 request envelope.
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// jsonRPCResponse is a JSON-RPC 2.0 response envelope.
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// jsonRPCError is a JSON-RPC 2.0 error object.
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+)
+
+// jsonRPCMethod is a reflected, callable JSON-RPC method of shape
+// func(context.Context, *Req) (*Resp, error).
+type jsonRPCMethod struct {
+	fn      reflect.Value
+	reqType reflect.Type
+}
+
+// RegisterJSONRPC registers every exported method of service whose
+// signature is func(context.Context, *Req) (*Resp, error) as a JSON-RPC
+// 2.0 endpoint at path, dispatching on the request's "method" field to the
+// Go method of the same name.
+func (s *Server) RegisterJSONRPC(path string, service any) {
+	methods := reflectJSONRPCMethods(service)
+	s.RegisterHandler(&Handler{
+		Path:    path,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { serveJSONRPC(w, r, methods) }),
+	})
+}
+
+// reflectJSONRPCMethods walks service's method set and keeps every method
+// matching func(context.Context, *Req) (*Resp, error). The second return
+// value must be exactly the error interface, not merely implement it: a
+// concrete error type would box a nil result into a non-nil error
+// interface, so serveJSONRPC's nil check at call time would misreport a
+// successful call as a JSON-RPC internal error.
+func reflectJSONRPCMethods(service any) map[string]jsonRPCMethod {
+	methods := make(map[string]jsonRPCMethod)
+
+	v := reflect.ValueOf(service)
+	t := v.Type()
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		ft := m.Func.Type()
+
+		// Receiver, context, *Req in; *Resp, error out.
+		if ft.NumIn() != 3 || ft.NumOut() != 2 {
+			continue
+		}
+		if !ft.In(1).Implements(ctxType) {
+			continue
+		}
+		if ft.In(2).Kind() != reflect.Ptr {
+			continue
+		}
+		if ft.Out(0).Kind() != reflect.Ptr || ft.Out(1) != errType {
+			continue
+		}
+
+		methods[m.Name] = jsonRPCMethod{fn: v.Method(i), reqType: ft.In(2).Elem()}
+	}
+	return methods
+}
+
+// serveJSONRPC decodes a jsonRPCRequest, dispatches it to the matching
+// method, and writes back a jsonRPCResponse.
+func serveJSONRPC(w http.ResponseWriter, r *http.Request, methods map[string]jsonRPCMethod) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req jsonRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONRPCError(w, nil, jsonRPCParseError, "parse error: "+err.Error())
+		return
+	}
+
+	method, ok := methods[req.Method]
+	if !ok {
+		writeJSONRPCError(w, req.ID, jsonRPCMethodNotFound, fmt.Sprintf("method not found: %s", req.Method))
+		return
+	}
+
+	reqVal := reflect.New(method.reqType)
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, reqVal.Interface()); err != nil {
+			writeJSONRPCError(w, req.ID, jsonRPCInvalidParams, "invalid params: "+err.Error())
+			return
+		}
+	}
+
+	out := method.fn.Call([]reflect.Value{reflect.ValueOf(r.Context()), reqVal})
+	if errVal := out[1].Interface(); errVal != nil {
+		writeJSONRPCError(w, req.ID, jsonRPCInternalError, errVal.(error).Error())
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+		JSONRPC: jsonRPCVersion,
+		Result:  out[0].Interface(),
+		ID:      req.ID,
+	})
+}
+
+// writeJSONRPCError writes a jsonRPCResponse carrying an error.
+func writeJSONRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+		JSONRPC: jsonRPCVersion,
+		Error:   &jsonRPCError{Code: code, Message: message},
+		ID:      id,
+	})
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type echoRequest struct {
+	Value string `json:"value"`
+}
+
+type echoResponse struct {
+	Value string `json:"value"`
+}
+
+type jsonrpcTestService struct{}
+
+func (jsonrpcTestService) Echo(_ context.Context, req *echoRequest) (*echoResponse, error) {
+	return &echoResponse{Value: req.Value}, nil
+}
+
+func (jsonrpcTestService) Fail(_ context.Context, _ *echoRequest) (*echoResponse, error) {
+	return nil, errors.New("boom")
+}
+
+// concreteErr is a named error type distinct from the error interface
+// itself, used to exercise reflectJSONRPCMethods's exact-type filter.
+type concreteErr struct{ msg string }
+
+func (e *concreteErr) Error() string { return e.msg }
+
+// BadSignature declares its error return as *concreteErr rather than the
+// error interface, so it must be excluded from the reflected method set:
+// a nil *concreteErr boxed into the interface slot would otherwise look
+// like a non-nil error to serveJSONRPC.
+func (jsonrpcTestService) BadSignature(_ context.Context, req *echoRequest) (*echoResponse, *concreteErr) {
+	return &echoResponse{Value: req.Value}, nil
+}
+
+func doJSONRPC(t *testing.T, methods map[string]jsonRPCMethod, body string) jsonRPCResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	serveJSONRPC(rec, req, methods)
+
+	var resp jsonRPCResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode JSON-RPC response: %v", err)
+	}
+	return resp
+}
+
+func TestReflectJSONRPCMethodsExcludesConcreteErrorType(t *testing.T) {
+	methods := reflectJSONRPCMethods(jsonrpcTestService{})
+	if _, ok := methods["BadSignature"]; ok {
+		t.Fatal("expected BadSignature (error return is *concreteErr, not error) to be excluded from reflected methods")
+	}
+	if _, ok := methods["Echo"]; !ok {
+		t.Fatal("expected Echo to be included in reflected methods")
+	}
+}
+
+func TestServeJSONRPCDispatchesSuccessfully(t *testing.T) {
+	methods := reflectJSONRPCMethods(jsonrpcTestService{})
+	resp := doJSONRPC(t, methods, `{"jsonrpc":"2.0","method":"Echo","params":{"value":"hi"},"id":1}`)
+
+	if resp.Error != nil {
+		t.Fatalf("expected no error for a successful call, got %+v", resp.Error)
+	}
+	b, err := json.Marshal(resp.Result)
+	if err != nil {
+		t.Fatalf("failed to marshal result: %v", err)
+	}
+	var got echoResponse
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if got.Value != "hi" {
+		t.Fatalf("expected echoed value %q, got %q", "hi", got.Value)
+	}
+}
+
+func TestServeJSONRPCMethodNotFound(t *testing.T) {
+	methods := reflectJSONRPCMethods(jsonrpcTestService{})
+	resp := doJSONRPC(t, methods, `{"jsonrpc":"2.0","method":"DoesNotExist","params":{},"id":1}`)
+
+	if resp.Error == nil || resp.Error.Code != jsonRPCMethodNotFound {
+		t.Fatalf("expected method-not-found error, got %+v", resp.Error)
+	}
+}
+
+func TestServeJSONRPCInvalidParams(t *testing.T) {
+	methods := reflectJSONRPCMethods(jsonrpcTestService{})
+	resp := doJSONRPC(t, methods, `{"jsonrpc":"2.0","method":"Echo","params":"not an object","id":1}`)
+
+	if resp.Error == nil || resp.Error.Code != jsonRPCInvalidParams {
+		t.Fatalf("expected invalid-params error, got %+v", resp.Error)
+	}
+}
+
+func TestServeJSONRPCInternalError(t *testing.T) {
+	methods := reflectJSONRPCMethods(jsonrpcTestService{})
+	resp := doJSONRPC(t, methods, `{"jsonrpc":"2.0","method":"Fail","params":{},"id":1}`)
+
+	if resp.Error == nil || resp.Error.Code != jsonRPCInternalError {
+		t.Fatalf("expected internal error, got %+v", resp.Error)
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// instrumentationMetrics holds the Prometheus collectors shared by every
+// request the PrometheusMiddleware instruments.
+type instrumentationMetrics struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	responseSize    *prometheus.HistogramVec
+}
+
+// NewPrometheusMiddleware returns a Middleware that records, for every
+// request, a request counter, a latency histogram, and a response-size
+// histogram, each labeled by route, method, and status code. namespace
+// prefixes every metric name, matching PoolConfig.PrometheusPrefix's role
+// in the worker package.
+func NewPrometheusMiddleware(namespace string) Middleware {
+	m := &instrumentationMetrics{
+		requestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Count of HTTP requests, labeled by route, method, and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency in seconds, labeled by route, method, and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		responseSize: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_response_size_bytes",
+			Help:      "HTTP response size in bytes, labeled by route, method, and status.",
+			Buckets:   prometheus.ExponentialBuckets(100, 10, 6), //nolint:gomnd // 100B..10MB.
+		}, []string{"route", "method", "status"}),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			route := r.URL.Path
+			status := strconv.Itoa(rec.status)
+
+			m.requestsTotal.WithLabelValues(route, r.Method, status).Inc()
+			m.requestDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+			m.responseSize.WithLabelValues(route, r.Method, status).Observe(float64(rec.size))
+		})
+	}
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code
+// and response size written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+// WriteHeader records status before delegating to the wrapped writer.
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written before delegating to the
+// wrapped writer.
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.size += n
+	return n, err
+}
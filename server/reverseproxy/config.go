@@ -0,0 +1,65 @@
+// Package reverseproxy implements a YAML-driven HTTP reverse proxy, with
+// per-backend retries and ordered failover, that registers as a regular
+// handler on a server.Server.
+package reverseproxy
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level reverse-proxy configuration, loaded from YAML.
+type Config struct {
+	// VHosts maps each virtual host to its ordered list of backends.
+	VHosts []VHost `yaml:"vhosts"`
+}
+
+// VHost is a single virtual host and the backends that serve it.
+type VHost struct {
+	// Host is matched against the incoming request's Host header.
+	Host string `yaml:"host"`
+	// Backends are tried in order; the first to succeed serves the
+	// request.
+	Backends []Backend `yaml:"backends"`
+}
+
+// Backend is a single upstream, along with its retry policy.
+type Backend struct {
+	// URL is the backend's base URL, e.g. "http://127.0.0.1:8081".
+	URL string `yaml:"url"`
+	// Retries is how many times to attempt this backend before failing
+	// over to the next one.
+	Retries int `yaml:"retries"`
+	// Delay is how long to wait between retry attempts against this
+	// backend.
+	Delay time.Duration `yaml:"delay"`
+	// Timeout bounds each individual attempt against this backend.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// LoadConfig reads and parses a Config from a YAML file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reverse proxy config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse reverse proxy config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// lookup returns the VHost matching host, or nil if none match.
+func (c *Config) lookup(host string) *VHost {
+	for i := range c.VHosts {
+		if c.VHosts[i].Host == host {
+			return &c.VHosts[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package reverseproxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// DefaultMaxBodyBytes bounds how much of a request body Handler buffers up
+// front so it can be replayed across backend attempts.
+const DefaultMaxBodyBytes = 4 << 20 // 4 MiB
+
+// Handler is an http.Handler that proxies requests to a vhost's ordered
+// list of backends, retrying each backend up to its configured Retries
+// before failing over to the next one.
+type Handler struct {
+	logger log.Logger
+	cfg    atomic.Pointer[Config]
+	client *http.Client
+
+	metrics proxyMetrics
+}
+
+// NewHandler creates a Handler serving cfg's vhosts. namespace prefixes
+// its Prometheus metrics, and must be unique per Handler instance in a
+// process to avoid duplicate metric registration.
+func NewHandler(namespace string, cfg *Config, logger log.Logger) *Handler {
+	h := &Handler{
+		logger:  logger,
+		client:  &http.Client{},
+		metrics: newProxyMetrics(namespace),
+	}
+	h.cfg.Store(cfg)
+	return h
+}
+
+// Reload atomically swaps in a new Config, e.g. in response to a SIGHUP or
+// a file-change notification (see Watch).
+func (h *Handler) Reload(cfg *Config) {
+	h.cfg.Store(cfg)
+	h.logger.Info("reverse proxy config reloaded")
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vhost := h.cfg.Load().lookup(r.Host)
+	if vhost == nil {
+		http.Error(w, "unknown vhost: "+r.Host, http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, DefaultMaxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > DefaultMaxBodyBytes {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	for _, backend := range vhost.Backends {
+		if h.tryBackend(w, r, vhost.Host, backend, body) {
+			return
+		}
+	}
+	h.metrics.outcomes.WithLabelValues(vhost.Host, "", "exhausted").Inc()
+	http.Error(w, "all backends unavailable", http.StatusBadGateway)
+}
+
+// tryBackend attempts backend up to backend.Retries times, sleeping
+// backend.Delay between attempts. It streams the response to w and
+// returns true on the first 2xx response; any other outcome (transport
+// error, non-2xx status) falls through to the next attempt, and to the
+// caller's next backend once retries are exhausted.
+func (h *Handler) tryBackend(w http.ResponseWriter, r *http.Request, vhost string, backend Backend, body []byte) bool {
+	retries := backend.Retries
+	if retries < 1 {
+		retries = 1
+	}
+
+	for attempt := 0; attempt < retries; attempt++ {
+		if attempt > 0 && backend.Delay > 0 {
+			time.Sleep(backend.Delay)
+		}
+
+		if h.attempt(w, r, vhost, backend, body) {
+			return true
+		}
+	}
+	return false
+}
+
+// attempt makes a single request to backend, bounded by backend.Timeout.
+// On a 2xx response, it copies the response to w and returns true; on any
+// other status, or a transport error, it reports the outcome and returns
+// false without writing to w, so the caller can retry or fail over.
+func (h *Handler) attempt(w http.ResponseWriter, r *http.Request, vhost string, backend Backend, body []byte) bool {
+	ctx := r.Context()
+	if backend.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, backend.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, backend.URL+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		h.metrics.outcomes.WithLabelValues(vhost, backend.URL, "error").Inc()
+		return false
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Set("X-Forwarded-For", r.RemoteAddr)
+	req.Header.Set("X-Forwarded-Proto", forwardedProto(r))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.metrics.outcomes.WithLabelValues(vhost, backend.URL, "error").Inc()
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		h.metrics.outcomes.WithLabelValues(vhost, backend.URL, "non_2xx").Inc()
+		return false
+	}
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+	h.metrics.outcomes.WithLabelValues(vhost, backend.URL, "success").Inc()
+	return true
+}
+
+// forwardedProto returns "https" if r was received over TLS, else "http".
+func forwardedProto(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
@@ -0,0 +1,144 @@
+package reverseproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testLogger is a no-op log.Logger stub, just enough to satisfy Handler in
+// tests without depending on a real logging backend.
+type testLogger struct{}
+
+func (testLogger) Info(_ string, _ ...any)  {}
+func (testLogger) Error(_ string, _ ...any) {}
+
+func newBackend(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+}
+
+func TestHandlerFailsOverToNextBackendInOrder(t *testing.T) {
+	down := newBackend(http.StatusServiceUnavailable)
+	defer down.Close()
+	up := newBackend(http.StatusOK)
+	defer up.Close()
+
+	cfg := &Config{VHosts: []VHost{{
+		Host: "example.com",
+		Backends: []Backend{
+			{URL: down.URL, Retries: 1},
+			{URL: up.URL, Retries: 1},
+		},
+	}}}
+	h := NewHandler("test_failover_order", cfg, testLogger{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "example.com"
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected failover to the second (healthy) backend to return 200, got %d", rec.Code)
+	}
+}
+
+func TestHandlerReturnsBadGatewayWhenAllBackendsFail(t *testing.T) {
+	down1 := newBackend(http.StatusServiceUnavailable)
+	defer down1.Close()
+	down2 := newBackend(http.StatusServiceUnavailable)
+	defer down2.Close()
+
+	cfg := &Config{VHosts: []VHost{{
+		Host: "example.com",
+		Backends: []Backend{
+			{URL: down1.URL, Retries: 1},
+			{URL: down2.URL, Retries: 1},
+		},
+	}}}
+	h := NewHandler("test_all_fail", cfg, testLogger{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "example.com"
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502 when every backend is exhausted, got %d", rec.Code)
+	}
+}
+
+func TestHandlerUnknownVHostReturnsNotFound(t *testing.T) {
+	cfg := &Config{VHosts: []VHost{{Host: "example.com", Backends: []Backend{{URL: "http://127.0.0.1:0", Retries: 1}}}}}
+	h := NewHandler("test_unknown_vhost", cfg, testLogger{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "other.example.com"
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unmatched vhost, got %d", rec.Code)
+	}
+}
+
+func TestHandlerBackendTimeoutFailsOverToNextBackend(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slow.Close()
+	fast := newBackend(http.StatusOK)
+	defer fast.Close()
+
+	cfg := &Config{VHosts: []VHost{{
+		Host: "example.com",
+		Backends: []Backend{
+			{URL: slow.URL, Retries: 1, Timeout: 10 * time.Millisecond},
+			{URL: fast.URL, Retries: 1},
+		},
+	}}}
+	h := NewHandler("test_backend_timeout", cfg, testLogger{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "example.com"
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected failover past the timed-out backend to return 200, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRetriesBeforeFailover(t *testing.T) {
+	var attempts int
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer flaky.Close()
+
+	cfg := &Config{VHosts: []VHost{{
+		Host:     "example.com",
+		Backends: []Backend{{URL: flaky.URL, Retries: 2}},
+	}}}
+	h := NewHandler("test_retries", cfg, testLogger{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "example.com"
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the second attempt against the same backend to succeed, got %d", rec.Code)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected exactly 2 attempts against the flaky backend, got %d", attempts)
+	}
+}
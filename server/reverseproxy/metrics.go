@@ -0,0 +1,24 @@
+package reverseproxy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// proxyMetrics holds the Prometheus collectors for a Handler.
+type proxyMetrics struct {
+	outcomes *prometheus.CounterVec
+}
+
+// newProxyMetrics registers and returns a Handler's Prometheus collectors
+// under namespace, so multiple Handlers (e.g. one per vhost config, or
+// repeated construction in tests) don't collide on the same metric names.
+func newProxyMetrics(namespace string) proxyMetrics {
+	return proxyMetrics{
+		outcomes: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reverseproxy_requests_total",
+			Help:      "Count of reverse-proxied requests, labeled by vhost, backend, and outcome.",
+		}, []string{"vhost", "backend", "outcome"}),
+	}
+}
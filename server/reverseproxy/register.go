@@ -0,0 +1,9 @@
+package reverseproxy
+
+import "github.com/berachain/offchain-sdk/server"
+
+// Register registers h with s at path, so it composes with the server's
+// other middlewares via the ordinary RegisterHandler API.
+func Register(s *server.Server, path string, h *Handler) {
+	s.RegisterHandler(&server.Handler{Path: path, Handler: h})
+}
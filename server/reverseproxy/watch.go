@@ -0,0 +1,64 @@
+package reverseproxy
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// Watch reloads h's configuration from path whenever the process receives
+// SIGHUP or the file changes on disk, until ctx is done. It is blocking
+// and should be run in a separate goroutine.
+func Watch(ctx context.Context, path string, h *Handler, logger log.Logger) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err = watcher.Add(path); err != nil {
+		return err
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sighup:
+			reload(path, h, logger)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				reload(path, h, logger)
+			}
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("reverse proxy config watcher error", "error", watchErr)
+		}
+	}
+}
+
+// reload loads path and, if it parses cleanly, installs it on h. A bad
+// config on disk is logged and left in place rather than taking down the
+// proxy.
+func reload(path string, h *Handler, logger log.Logger) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		logger.Error("failed to reload reverse proxy config, keeping previous config", "error", err)
+		return
+	}
+	h.Reload(cfg)
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Route describes a single HTTP route: a method, a path pattern (using the
+// `net/http` 1.22+ ServeMux pattern syntax, e.g. "/users/{id}"), its
+// handler, and the middleware stack that applies only to this route, on
+// top of the server's global middlewares.
+type Route struct {
+	// Method is the HTTP method to match, e.g. http.MethodGet. Empty
+	// matches any method.
+	Method string
+	// Path is the route's path pattern, relative to its HandlerGroup's
+	// prefix (if any).
+	Path string
+	// Handler serves the route.
+	Handler http.Handler
+	// Middlewares are applied to Handler, innermost last, before the
+	// server's global middlewares.
+	Middlewares []Middleware
+}
+
+// pattern returns the Route's `net/http` ServeMux pattern, e.g.
+// "GET /users/{id}".
+func (r *Route) pattern() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return r.Method + " " + r.Path
+}
+
+// HandlerGroup is a collection of Routes that share a path prefix and a
+// common middleware stack, so a family of related routes can be declared
+// and registered together instead of calling RegisterHandler once per
+// route.
+type HandlerGroup struct {
+	prefix      string
+	middlewares []Middleware
+	routes      []Route
+}
+
+// NewHandlerGroup creates a HandlerGroup rooted at prefix. middlewares are
+// applied to every route added to the group (and to any sub-groups), in
+// addition to whatever middlewares are passed to Handle itself.
+func NewHandlerGroup(prefix string, middlewares ...Middleware) *HandlerGroup {
+	return &HandlerGroup{prefix: prefix, middlewares: middlewares}
+}
+
+// Group creates a sub-group nested under this group's prefix, inheriting
+// its middlewares in addition to any provided here. The sub-group keeps its
+// own routes: RegisterHandlerGroup on the parent does not register them.
+func (g *HandlerGroup) Group(prefix string, middlewares ...Middleware) *HandlerGroup {
+	return &HandlerGroup{
+		prefix:      g.prefix + prefix,
+		middlewares: append(append([]Middleware{}, g.middlewares...), middlewares...),
+	}
+}
+
+// Handle registers a route on the group for method and path (relative to
+// the group's prefix), applying any route-specific middlewares on top of
+// the group's. method may be empty to match any method.
+func (g *HandlerGroup) Handle(method, path string, h http.Handler, middlewares ...Middleware) {
+	g.routes = append(g.routes, Route{
+		Method:      method,
+		Path:        g.prefix + path,
+		Handler:     h,
+		Middlewares: append(append([]Middleware{}, g.middlewares...), middlewares...),
+	})
+}
+
+// RegisterRoute registers a single Route with the server, wrapping its
+// Handler with its route-specific middlewares before the server's global
+// middlewares apply. If s.cfg.HTTP.RouteOverrides has an entry for r.Path,
+// an admission middleware built from it wraps this route, under its own
+// Prometheus namespace so it doesn't collide with the server-wide
+// admission middleware or another route's override. It does not replace
+// the server-wide admission control: that stays the outermost middleware,
+// so a request to this route must be admitted by both.
+func (s *Server) RegisterRoute(r Route) {
+	h := r.Handler
+	for i := len(r.Middlewares) - 1; i >= 0; i-- {
+		h = r.Middlewares[i](h)
+	}
+	if override, ok := s.cfg.HTTP.RouteOverrides[r.Path]; ok {
+		namespace := s.cfg.HTTP.PrometheusPrefix + "_route_" + sanitizeMetricName(r.Path)
+		h = NewAdmissionMiddleware(namespace, override)(h)
+	}
+	s.mux.Handle(r.pattern(), h)
+}
+
+// sanitizeMetricName replaces every rune not valid in a Prometheus metric
+// name component with an underscore, so a route path (which may contain
+// "/" and ServeMux "{...}" wildcards) can be used as part of a namespace.
+func sanitizeMetricName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// RegisterHandlerGroup registers every route in a HandlerGroup with the
+// server.
+func (s *Server) RegisterHandlerGroup(g *HandlerGroup) {
+	for _, r := range g.routes {
+		s.RegisterRoute(r)
+	}
+}
+
+// RegisterJSON registers a typed JSON handler for method and path: fn's
+// request type is decoded from the request body (if any) and its response
+// is marshaled to JSON. It is a package-level function, rather than a
+// Server method, because Go methods cannot carry their own type
+// parameters.
+func RegisterJSON[Req, Resp any](s *Server, method, path string, fn func(*http.Request, Req) (Resp, error)) {
+	s.RegisterRoute(Route{
+		Method: method,
+		Path:   path,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req Req
+			if r.Body != nil && r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+
+			resp, err := fn(r, req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if err = json.NewEncoder(w).Encode(resp); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -25,16 +26,24 @@ type Middleware func(http.Handler) http.Handler
 
 // Server represents an HTTP server with configurable middleware and handlers.
 type Server struct {
-	cfg          *Config
-	logger       log.Logger
-	mux          *http.ServeMux
-	srv          *http.Server
-	closer       sync.Once
-	middlewares  []Middleware
+	cfg         *Config
+	logger      log.Logger
+	mux         *http.ServeMux
+	srv         *http.Server
+	closer      sync.Once
+	middlewares []Middleware
 }
 
-// New creates and returns a new Server instance.
+// New creates and returns a new Server instance. If cfg.HTTP.
+// MaxConcurrentRequests is set, an AdmissionMiddleware built from cfg.HTTP.
+// AdmissionConfig is installed as the outermost middleware, ahead of any
+// middlewares passed in, so overload shedding happens before any other
+// middleware runs.
 func New(cfg *Config, logger log.Logger, middlewares ...Middleware) *Server {
+	if cfg.HTTP.MaxConcurrentRequests > 0 {
+		admission := NewAdmissionMiddleware(cfg.HTTP.PrometheusPrefix, cfg.HTTP.AdmissionConfig)
+		middlewares = append([]Middleware{admission}, middlewares...)
+	}
 	return &Server{
 		cfg:         cfg,
 		logger:      logger,
@@ -54,7 +63,8 @@ func (s *Server) RegisterMiddleware(m Middleware) {
 }
 
 // applyMiddlewares applies the middlewares to the server's handler in reverse order.
-// The last middleware in the slice will be the outermost.
+// The first middleware in the slice will be the outermost, i.e. it runs
+// before every other middleware and the mux itself.
 func (s *Server) applyMiddlewares() http.Handler {
 	var h http.Handler = s.mux
 	for i := len(s.middlewares) - 1; i >= 0; i-- {
@@ -63,18 +73,40 @@ func (s *Server) applyMiddlewares() http.Handler {
 	return h
 }
 
-// Start initializes and starts the server.
+// Handler returns the server's fully wrapped HTTP handler (mux plus every
+// middleware). Other transports that need to share this server's routes
+// and middleware on a different listener — e.g. GRPCServer's MuxHTTP mode
+// — should use this instead of reimplementing routing.
+func (s *Server) Handler() http.Handler {
+	return s.applyMiddlewares()
+}
+
+// Start initializes and starts the server, listening on Config.HTTP.Host
+// and Config.HTTP.Port.
 // This method is blocking and should be run in a separate goroutine.
 func (s *Server) Start(ctx context.Context) {
+	addr := fmt.Sprintf("%s:%d", s.cfg.HTTP.Host, s.cfg.HTTP.Port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.logger.Error("failed to listen", "address", addr, "error", err)
+		return
+	}
+	s.Serve(ctx, lis)
+}
+
+// Serve runs the server on a caller-provided listener instead of opening
+// its own, so the listener can be shared with another transport (e.g. a
+// GRPCServer in MuxHTTP mode, via cmux). It otherwise behaves exactly like
+// Start: blocking and graceful-shutdown-on-ctx-done.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) {
 	s.srv = &http.Server{
-		Addr:              fmt.Sprintf("%s:%d", s.cfg.HTTP.Host, s.cfg.HTTP.Port),
 		Handler:           s.applyMiddlewares(),
 		ReadHeaderTimeout: DefaultReadHeaderTimeout,
 	}
 
 	go func() {
-		s.logger.Info("Starting HTTP server", "address", s.srv.Addr)
-		if err := s.srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		s.logger.Info("Starting HTTP server", "address", lis.Addr().String())
+		if err := s.srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("HTTP server error", "error", err)
 		}
 	}()
@@ -46,4 +46,4 @@ func ResizerFromString(name string) pond.ResizingStrategy {
 	default:
 		panic("invalid resizer name")
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,227 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// Outcome classifies how a dispatched job finished, so the Dispatcher can
+// decide whether to grow or shrink the pool it controls.
+type Outcome int
+
+const (
+	// OutcomeOK indicates the job completed normally.
+	OutcomeOK Outcome = iota
+	// OutcomeThrottled indicates the job was rejected or rate-limited by a
+	// downstream dependency (e.g. an HTTP 503 or RPC rate-limit error).
+	OutcomeThrottled
+	// OutcomeError indicates the job failed for a reason unrelated to load.
+	OutcomeError
+	// OutcomeDeadlineExceeded indicates the job was dropped by a
+	// PriorityPool because its deadline elapsed while still queued.
+	OutcomeDeadlineExceeded
+)
+
+// JobResult is reported by a submitted job so the Dispatcher can classify
+// its outcome.
+type JobResult struct {
+	Outcome Outcome
+}
+
+// DispatcherConfig configures the AIMD load controller.
+type DispatcherConfig struct {
+	// Tick is how often the dispatcher samples load and re-evaluates the
+	// pool size.
+	Tick time.Duration
+	// HighWatermark is the queue depth above which the dispatcher starts
+	// counting consecutive ticks toward a grow decision.
+	HighWatermark int
+	// TicksAboveWatermark is the number of consecutive ticks queue depth
+	// must exceed HighWatermark before the dispatcher grows the pool.
+	TicksAboveWatermark int
+	// GrowBy is how many workers to add to the cap on a grow decision.
+	GrowBy int
+	// ThrottleCooldown is how long after the most recent throttle signal
+	// the dispatcher waits before restoring the cap to MaxWorkers in one
+	// step. The grow rule still applies during the cooldown; growth is not
+	// held below the post-throttle cap.
+	ThrottleCooldown time.Duration
+}
+
+// DefaultDispatcherConfig is the default configuration for a Dispatcher.
+func DefaultDispatcherConfig() *DispatcherConfig {
+	return &DispatcherConfig{
+		Tick:                time.Second,
+		HighWatermark:       10,               //nolint:gomnd // it's ok.
+		TicksAboveWatermark: 3,                //nolint:gomnd // it's ok.
+		GrowBy:              2,                //nolint:gomnd // it's ok.
+		ThrottleCooldown:    30 * time.Second, //nolint:gomnd // it's ok.
+	}
+}
+
+// Dispatcher samples queue depth, in-flight jobs, and throttle signals on a
+// fixed tick and resizes its attached Pool between PoolConfig.MinWorkers
+// and PoolConfig.MaxWorkers using an AIMD rule: grow by DispatcherConfig.
+// GrowBy when queue depth exceeds HighWatermark for TicksAboveWatermark
+// consecutive ticks, and halve the cap toward MinWorkers whenever a job
+// reports OutcomeThrottled. The cap decays back to MaxWorkers
+// ThrottleCooldown after the most recent throttle.
+type Dispatcher struct {
+	pool   *Pool
+	cfg    *DispatcherConfig
+	logger log.Logger
+
+	ticker *time.Ticker
+	done   chan struct{}
+	closer sync.Once
+
+	mu           sync.Mutex
+	cap          int
+	highTicks    int
+	lastThrottle time.Time
+
+	metrics dispatcherMetrics
+}
+
+type dispatcherMetrics struct {
+	cap          prometheus.Gauge
+	queueDepth   prometheus.Gauge
+	runningJobs  prometheus.Gauge
+	lastThrottle prometheus.Gauge
+	decisions    *prometheus.CounterVec
+}
+
+// NewDispatcher creates a new Dispatcher for the given pool configuration.
+// The returned Dispatcher must be attached to a Pool via Pool.
+// WithDispatcher and started with Start.
+func NewDispatcher(poolCfg *PoolConfig, cfg *DispatcherConfig, logger log.Logger) *Dispatcher {
+	return &Dispatcher{
+		cfg:    cfg,
+		logger: logger,
+		done:   make(chan struct{}),
+		cap:    poolCfg.MaxWorkers,
+		metrics: dispatcherMetrics{
+			cap: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: poolCfg.PrometheusPrefix + "_dispatcher_max_concurrency",
+				Help: "Current cap on concurrent workers, as decided by the dispatcher.",
+			}),
+			queueDepth: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: poolCfg.PrometheusPrefix + "_dispatcher_queue_depth",
+				Help: "Number of jobs waiting for a free worker at the last sample.",
+			}),
+			runningJobs: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: poolCfg.PrometheusPrefix + "_dispatcher_running_jobs",
+				Help: "Number of jobs currently running at the last sample.",
+			}),
+			lastThrottle: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: poolCfg.PrometheusPrefix + "_dispatcher_last_throttle_timestamp",
+				Help: "Unix timestamp of the most recent throttle signal observed.",
+			}),
+			decisions: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: poolCfg.PrometheusPrefix + "_dispatcher_resize_decisions_total",
+				Help: "Count of grow/shrink decisions made by the dispatcher, labeled by decision.",
+			}, []string{"decision"}),
+		},
+	}
+}
+
+// Start begins sampling load on Dispatcher.Tick and resizing the attached
+// pool accordingly. It blocks until ctx is done or Stop is called, and
+// should be run in a separate goroutine.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.ticker = time.NewTicker(d.cfg.Tick)
+	defer d.ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.done:
+			return
+		case <-d.ticker.C:
+			d.tick()
+		}
+	}
+}
+
+// Stop halts the dispatcher's sampling loop.
+func (d *Dispatcher) Stop() {
+	d.closer.Do(func() {
+		close(d.done)
+	})
+}
+
+// ReportResult is called by job submitters (via Pool.SubmitWithResult) to
+// report how a job completed, so the dispatcher can react to throttle
+// signals immediately rather than waiting for the next tick's queue-depth
+// sample.
+func (d *Dispatcher) ReportResult(result JobResult) {
+	if result.Outcome != OutcomeThrottled {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastThrottle = time.Now()
+	d.metrics.lastThrottle.Set(float64(d.lastThrottle.Unix()))
+
+	newCap := d.pool.cfg.MinWorkers + (d.cap-d.pool.cfg.MinWorkers)/2 //nolint:gomnd // halve toward MinWorkers.
+	if newCap < d.pool.cfg.MinWorkers {
+		newCap = d.pool.cfg.MinWorkers
+	}
+	if newCap == d.cap {
+		return
+	}
+	d.cap = newCap
+	d.highTicks = 0
+	d.metrics.decisions.WithLabelValues("shrink").Inc()
+	d.applyCapLocked()
+}
+
+// tick samples current load and applies the AIMD grow rule, plus the
+// post-throttle cooldown decay.
+func (d *Dispatcher) tick() {
+	queueDepth := d.pool.WaitingTasks()
+	running := d.pool.RunningWorkers()
+	d.metrics.queueDepth.Set(float64(queueDepth))
+	d.metrics.runningJobs.Set(float64(running))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if int(queueDepth) > d.cfg.HighWatermark {
+		d.highTicks++
+	} else {
+		d.highTicks = 0
+	}
+
+	if d.highTicks >= d.cfg.TicksAboveWatermark {
+		d.highTicks = 0
+		if newCap := d.cap + d.cfg.GrowBy; newCap <= d.pool.cfg.MaxWorkers && newCap != d.cap {
+			d.cap = newCap
+			d.metrics.decisions.WithLabelValues("grow").Inc()
+			d.applyCapLocked()
+		}
+	}
+
+	if !d.lastThrottle.IsZero() && time.Since(d.lastThrottle) > d.cfg.ThrottleCooldown && d.cap < d.pool.cfg.MaxWorkers {
+		d.cap = d.pool.cfg.MaxWorkers
+		d.metrics.decisions.WithLabelValues("decay").Inc()
+		d.applyCapLocked()
+	}
+}
+
+// applyCapLocked pushes the current cap to the pool and its gauge. Callers
+// must hold d.mu.
+func (d *Dispatcher) applyCapLocked() {
+	d.pool.Resize(d.cap)
+	d.metrics.cap.Set(float64(d.cap))
+	d.logger.Info("dispatcher resized pool", "cap", d.cap)
+}
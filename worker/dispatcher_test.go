@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T, minWorkers, maxWorkers int) *Pool {
+	t.Helper()
+	cfg := &PoolConfig{
+		Name:             t.Name(),
+		PrometheusPrefix: "test_" + t.Name(),
+		MinWorkers:       minWorkers,
+		MaxWorkers:       maxWorkers,
+		ResizingStrategy: "balanced",
+		MaxQueuedJobs:    100, //nolint:gomnd // plenty of headroom for these tests.
+	}
+	pool := NewPool(cfg, testLogger{})
+	t.Cleanup(pool.StopAndWait)
+	return pool
+}
+
+func TestDispatcherReportResultHalvesCapTowardMinWorkers(t *testing.T) {
+	pool := newTestPool(t, 1, 10)
+	d := NewDispatcher(pool.cfg, DefaultDispatcherConfig(), testLogger{})
+	pool.WithDispatcher(d)
+
+	if d.cap != 10 {
+		t.Fatalf("expected initial cap to be MaxWorkers (10), got %d", d.cap)
+	}
+
+	d.ReportResult(JobResult{Outcome: OutcomeThrottled})
+	if want := 1 + (10-1)/2; d.cap != want { // halve toward MinWorkers
+		t.Fatalf("after one throttle, expected cap %d, got %d", want, d.cap)
+	}
+
+	prevCap := d.cap
+	d.ReportResult(JobResult{Outcome: OutcomeThrottled})
+	if want := 1 + (prevCap-1)/2; d.cap != want {
+		t.Fatalf("after a second throttle, expected cap %d, got %d", want, d.cap)
+	}
+
+	// A non-throttled outcome must never shrink the cap.
+	prevCap = d.cap
+	d.ReportResult(JobResult{Outcome: OutcomeOK})
+	if d.cap != prevCap {
+		t.Fatalf("expected OutcomeOK to leave cap unchanged at %d, got %d", prevCap, d.cap)
+	}
+}
+
+func TestDispatcherReportResultNeverShrinksBelowMinWorkers(t *testing.T) {
+	pool := newTestPool(t, 4, 5)
+	d := NewDispatcher(pool.cfg, DefaultDispatcherConfig(), testLogger{})
+	pool.WithDispatcher(d)
+
+	for i := 0; i < 10; i++ {
+		d.ReportResult(JobResult{Outcome: OutcomeThrottled})
+	}
+	if d.cap != 4 {
+		t.Fatalf("expected repeated throttles to floor out at MinWorkers (4), got %d", d.cap)
+	}
+}
+
+func TestDispatcherGrowsAfterConsecutiveHighWatermarkTicks(t *testing.T) {
+	pool := newTestPool(t, 1, 10)
+	dispatcherCfg := &DispatcherConfig{
+		Tick:                time.Hour, // ticks are driven manually below
+		HighWatermark:       0,
+		TicksAboveWatermark: 2,
+		GrowBy:              3,
+		ThrottleCooldown:    time.Hour,
+	}
+	d := NewDispatcher(pool.cfg, dispatcherCfg, testLogger{})
+	pool.WithDispatcher(d)
+
+	// Shrink the cap first so there's room to observe growth (the cap
+	// starts pinned at MaxWorkers).
+	d.ReportResult(JobResult{Outcome: OutcomeThrottled})
+	shrunkCap := d.cap
+
+	// Occupy every worker and queue more jobs behind them, to keep queue
+	// depth above HighWatermark across ticks.
+	block := make(chan struct{})
+	defer close(block)
+	started := make(chan struct{}, shrunkCap)
+	for i := 0; i < shrunkCap+2; i++ {
+		pool.Submit(func() {
+			started <- struct{}{}
+			<-block
+		})
+	}
+	for i := 0; i < shrunkCap; i++ {
+		<-started
+	}
+
+	d.tick()
+	if d.cap != shrunkCap {
+		t.Fatalf("expected cap to stay at %d after only one high-watermark tick, got %d", shrunkCap, d.cap)
+	}
+	d.tick()
+	if want := shrunkCap + dispatcherCfg.GrowBy; d.cap != want {
+		t.Fatalf("expected cap to grow to %d after %d consecutive high-watermark ticks, got %d",
+			want, dispatcherCfg.TicksAboveWatermark, d.cap)
+	}
+}
+
+func TestDispatcherCapNeverExceedsMaxWorkers(t *testing.T) {
+	pool := newTestPool(t, 1, 4)
+	dispatcherCfg := &DispatcherConfig{
+		Tick:                time.Hour,
+		HighWatermark:       0,
+		TicksAboveWatermark: 1,
+		GrowBy:              100, //nolint:gomnd // deliberately oversized to test the MaxWorkers ceiling.
+		ThrottleCooldown:    time.Hour,
+	}
+	d := NewDispatcher(pool.cfg, dispatcherCfg, testLogger{})
+	pool.WithDispatcher(d)
+
+	block := make(chan struct{})
+	defer close(block)
+	started := make(chan struct{}, 4)
+	for i := 0; i < 6; i++ {
+		pool.Submit(func() {
+			started <- struct{}{}
+			<-block
+		})
+	}
+	for i := 0; i < 4; i++ {
+		<-started
+	}
+
+	d.tick()
+	if d.cap != 4 {
+		t.Fatalf("expected cap to stay pinned at MaxWorkers (4) even with a huge GrowBy, got %d", d.cap)
+	}
+}
+
+func TestDispatcherDecaysBackToMaxWorkersAfterCooldown(t *testing.T) {
+	pool := newTestPool(t, 1, 10)
+	dispatcherCfg := &DispatcherConfig{
+		Tick:                time.Hour,
+		HighWatermark:       1000, //nolint:gomnd // high enough that queue depth never trips the grow path here.
+		TicksAboveWatermark: 1000,
+		GrowBy:              1,
+		ThrottleCooldown:    20 * time.Millisecond,
+	}
+	d := NewDispatcher(pool.cfg, dispatcherCfg, testLogger{})
+	pool.WithDispatcher(d)
+
+	d.ReportResult(JobResult{Outcome: OutcomeThrottled})
+	if d.cap == 10 {
+		t.Fatalf("expected throttle to shrink cap below MaxWorkers")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	d.tick()
+	if d.cap != 10 {
+		t.Fatalf("expected cap to decay back to MaxWorkers (10) after the cooldown elapsed, got %d", d.cap)
+	}
+}
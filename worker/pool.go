@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/alitto/pond"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// Pool wraps a pond worker pool, optionally paired with a Dispatcher that
+// resizes it at runtime in response to observed load (see Dispatcher).
+//
+// pond v1's WorkerPool has no runtime resize API, so its underlying pool
+// is always sized at cfg.MaxWorkers; Resize instead adjusts a token-based
+// concurrency limiter layered on top, which is what actually lets fewer
+// than MaxWorkers jobs run at once.
+type Pool struct {
+	cfg    *PoolConfig
+	logger log.Logger
+	pool   *pond.WorkerPool
+
+	// tokens is a counting semaphore: its capacity is cfg.MaxWorkers, and
+	// the number of tokens in circulation (in the channel, or held by a
+	// running job) is the current concurrency cap.
+	tokens chan struct{}
+
+	mu      sync.Mutex
+	cap     int // current concurrency cap, in [MinWorkers, MaxWorkers]
+	deficit int // tokens to withhold on release, owed by a shrink that couldn't pull one back immediately
+
+	dispatcher *Dispatcher
+}
+
+// NewPool creates and returns a new Pool using the given configuration.
+// Concurrency starts at cfg.MaxWorkers; use WithDispatcher to let a
+// Dispatcher adjust it at runtime.
+func NewPool(cfg *PoolConfig, logger log.Logger) *Pool {
+	p := &Pool{
+		cfg:    cfg,
+		logger: logger,
+		pool: pond.New(
+			cfg.MaxWorkers,
+			cfg.MaxQueuedJobs,
+			pond.MinWorkers(cfg.MinWorkers),
+			pond.Strategy(ResizerFromString(cfg.ResizingStrategy)),
+		),
+		tokens: make(chan struct{}, cfg.MaxWorkers),
+		cap:    cfg.MaxWorkers,
+	}
+	for i := 0; i < cfg.MaxWorkers; i++ {
+		p.tokens <- struct{}{}
+	}
+	return p
+}
+
+// WithDispatcher attaches a Dispatcher that will resize the pool between
+// cfg.MinWorkers and cfg.MaxWorkers in response to load and throttle
+// signals. It returns the Pool for chaining.
+func (p *Pool) WithDispatcher(d *Dispatcher) *Pool {
+	d.pool = p
+	p.dispatcher = d
+	return p
+}
+
+// Submit submits a job to the pool, blocking until a concurrency token is
+// available (i.e. fewer than the current cap are already running).
+func (p *Pool) Submit(fn func()) {
+	<-p.tokens
+	p.pool.Submit(func() {
+		defer p.release()
+		fn()
+	})
+}
+
+// SubmitWithResult submits a job to the pool and reports its JobResult to
+// the attached Dispatcher, if any, so the dispatcher can classify load and
+// throttle signals. Callers that don't need dispatcher feedback should use
+// Submit instead.
+func (p *Pool) SubmitWithResult(fn func() JobResult) {
+	p.Submit(func() {
+		result := fn()
+		if p.dispatcher != nil {
+			p.dispatcher.ReportResult(result)
+		}
+	})
+}
+
+// release returns fn's concurrency token, unless a prior Resize shrunk the
+// cap and is still owed a withheld token, in which case the token is
+// swallowed instead, so the net effect of that shrink finally takes hold.
+func (p *Pool) release() {
+	p.mu.Lock()
+	if p.deficit > 0 {
+		p.deficit--
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	p.tokens <- struct{}{}
+}
+
+// RunningWorkers returns the number of workers currently running jobs.
+func (p *Pool) RunningWorkers() int {
+	return p.pool.Running()
+}
+
+// WaitingTasks returns the number of jobs currently queued, waiting for a
+// free worker.
+func (p *Pool) WaitingTasks() uint64 {
+	return p.pool.WaitingTasks()
+}
+
+// Resize adjusts the pool's concurrency cap, clamped to [MinWorkers,
+// MaxWorkers]. Growing immediately returns extra tokens to the pool;
+// shrinking pulls tokens back where it can, and otherwise records a
+// deficit so the next job(s) to finish don't return their token.
+func (p *Pool) Resize(n int) {
+	if n < p.cfg.MinWorkers {
+		n = p.cfg.MinWorkers
+	}
+	if n > p.cfg.MaxWorkers {
+		n = p.cfg.MaxWorkers
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	diff := n - p.cap
+	p.cap = n
+
+	switch {
+	case diff > 0:
+		for i := 0; i < diff; i++ {
+			p.tokens <- struct{}{}
+		}
+	case diff < 0:
+		for i := 0; i < -diff; i++ {
+			select {
+			case <-p.tokens:
+			default:
+				p.deficit++
+			}
+		}
+	}
+}
+
+// StopAndWait stops the pool and waits for all queued and running jobs to
+// complete.
+func (p *Pool) StopAndWait() {
+	if p.dispatcher != nil {
+		p.dispatcher.Stop()
+	}
+	p.pool.StopAndWait()
+}
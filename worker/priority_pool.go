@@ -0,0 +1,299 @@
+package worker
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// PriorityPoolConfig is the configuration for a PriorityPool.
+type PriorityPoolConfig struct {
+	// Name is the name of the pool.
+	Name string
+	// PrometheusPrefix is the prefix for the prometheus metrics.
+	PrometheusPrefix string
+	// Workers is the fixed number of workers pulling jobs off the
+	// priority queue.
+	Workers int
+	// SupervisorFraction caps the fraction of Workers (rounded up to at
+	// least 1) that may be executing a Supervisor-tagged job at once, so
+	// long-running coordinator jobs cannot starve leaf jobs.
+	SupervisorFraction float64
+}
+
+// DefaultPriorityPoolConfig is the default configuration for a
+// PriorityPool.
+func DefaultPriorityPoolConfig() *PriorityPoolConfig {
+	return &PriorityPoolConfig{
+		Name:               "default",
+		PrometheusPrefix:   "default",
+		Workers:            32,  //nolint:gomnd // it's ok.
+		SupervisorFraction: 0.2, //nolint:gomnd // it's ok.
+	}
+}
+
+// JobOption customizes a PriorityJob at submission time.
+type JobOption func(*PriorityJob)
+
+// WithSupervisor marks a job as supervisor/orchestration work, subjecting
+// it to the pool's SupervisorFraction cap.
+func WithSupervisor() JobOption {
+	return func(j *PriorityJob) { j.Supervisor = true }
+}
+
+// priorityMetrics holds the Prometheus collectors for a PriorityPool.
+type priorityMetrics struct {
+	queueDepth      prometheus.Gauge
+	deadlineDropped prometheus.Counter
+	dispatched      *prometheus.CounterVec
+}
+
+// PriorityPool is a fixed-size worker pool backed by a binary heap keyed
+// by (priority desc, enqueueTime asc), rather than pond's FIFO queue. A
+// single dispatcher goroutine pops the heap under a mutex, blocking on a
+// sync.Cond when it's empty, and hands eligible jobs to idle workers over
+// a channel.
+type PriorityPool struct {
+	cfg    *PriorityPoolConfig
+	logger log.Logger
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	heap jobHeap
+
+	ready   chan *PriorityJob
+	stopped chan struct{}
+	closer  sync.Once
+	wg      sync.WaitGroup
+
+	supervisorActive int64
+
+	metrics priorityMetrics
+}
+
+// NewPriorityPool creates a PriorityPool and starts its dispatcher and
+// worker goroutines.
+func NewPriorityPool(cfg *PriorityPoolConfig, logger log.Logger) *PriorityPool {
+	p := &PriorityPool{
+		cfg:     cfg,
+		logger:  logger,
+		ready:   make(chan *PriorityJob),
+		stopped: make(chan struct{}),
+		metrics: priorityMetrics{
+			queueDepth: promauto.NewGauge(prometheus.GaugeOpts{
+				Name: cfg.PrometheusPrefix + "_priority_queue_depth",
+				Help: "Number of jobs currently queued in the priority pool.",
+			}),
+			deadlineDropped: promauto.NewCounter(prometheus.CounterOpts{
+				Name: cfg.PrometheusPrefix + "_priority_queue_deadline_dropped_total",
+				Help: "Count of jobs dropped because their deadline elapsed while queued.",
+			}),
+			dispatched: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name: cfg.PrometheusPrefix + "_priority_queue_dispatched_total",
+				Help: "Count of jobs dispatched to a worker, labeled by whether they are supervisor jobs.",
+			}, []string{"supervisor"}),
+		},
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	p.wg.Add(1)
+	go p.dispatch()
+	for i := 0; i < cfg.Workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+// SubmitWithPriority enqueues fn with the given priority. If ctx has a
+// deadline, the job is dropped with OutcomeDeadlineExceeded if it is still
+// queued once that deadline elapses. The returned channel receives fn's
+// JobResult, or the dropped result, exactly once; a job still queued when
+// the pool is stopped receives nothing.
+func (p *PriorityPool) SubmitWithPriority(
+	ctx context.Context,
+	prio int,
+	fn func(ctx context.Context) JobResult,
+	opts ...JobOption,
+) <-chan JobResult {
+	job := &PriorityJob{
+		Priority:   prio,
+		EnqueuedAt: time.Now(),
+		Fn:         fn,
+		done:       make(chan JobResult, 1),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		job.Deadline = deadline
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	p.mu.Lock()
+	heap.Push(&p.heap, job)
+	p.metrics.queueDepth.Set(float64(p.heap.Len()))
+	p.cond.Signal()
+	p.mu.Unlock()
+
+	return job.done
+}
+
+// supervisorCap returns the maximum number of supervisor-tagged jobs
+// allowed to run concurrently.
+func (p *PriorityPool) supervisorCap() int64 {
+	c := int64(float64(p.cfg.Workers) * p.cfg.SupervisorFraction)
+	if c < 1 {
+		c = 1
+	}
+	return c
+}
+
+// popEligibleLocked pops the highest-priority job that's eligible to run
+// right now (i.e. not a supervisor job over the supervisor cap), pushing
+// back any supervisor jobs it skipped over. Callers must hold p.mu.
+func (p *PriorityPool) popEligibleLocked() *PriorityJob {
+	var held []*PriorityJob
+	var chosen *PriorityJob
+
+	for p.heap.Len() > 0 {
+		job, _ := heap.Pop(&p.heap).(*PriorityJob)
+		if job.Supervisor && atomic.LoadInt64(&p.supervisorActive) >= p.supervisorCap() {
+			held = append(held, job)
+			continue
+		}
+		chosen = job
+		break
+	}
+	for _, job := range held {
+		heap.Push(&p.heap, job)
+	}
+	return chosen
+}
+
+// dispatch is the single goroutine that owns the heap: it pops eligible
+// jobs and hands them to an idle worker via p.ready.
+func (p *PriorityPool) dispatch() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		for p.heap.Len() == 0 {
+			select {
+			case <-p.stopped:
+				p.mu.Unlock()
+				return
+			default:
+			}
+			p.cond.Wait()
+		}
+
+		job := p.popEligibleLocked()
+		if job == nil {
+			// Every queued job is a supervisor job over the cap; wait for
+			// a running supervisor job to finish and retry.
+			select {
+			case <-p.stopped:
+				p.mu.Unlock()
+				return
+			default:
+			}
+			p.cond.Wait()
+			p.mu.Unlock()
+			continue
+		}
+		p.metrics.queueDepth.Set(float64(p.heap.Len()))
+		p.mu.Unlock()
+
+		if !job.Deadline.IsZero() && time.Now().After(job.Deadline) {
+			p.metrics.deadlineDropped.Inc()
+			job.done <- JobResult{Outcome: OutcomeDeadlineExceeded}
+			continue
+		}
+
+		select {
+		case p.ready <- job:
+		case <-p.stopped:
+			return
+		}
+	}
+}
+
+// work runs jobs handed to it by dispatch until the pool is stopped.
+func (p *PriorityPool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case job, ok := <-p.ready:
+			if !ok {
+				return
+			}
+			p.runJob(job)
+		case <-p.stopped:
+			return
+		}
+	}
+}
+
+// runJob executes a single job, deriving its context from job.Deadline if
+// set, and reports the outcome back to the job's submitter.
+func (p *PriorityPool) runJob(job *PriorityJob) {
+	// A job can outlive its deadline while the dispatcher waits for an idle
+	// worker to take it, so check again before running it.
+	if !job.Deadline.IsZero() && time.Now().After(job.Deadline) {
+		p.metrics.deadlineDropped.Inc()
+		job.done <- JobResult{Outcome: OutcomeDeadlineExceeded}
+		return
+	}
+
+	supervisorLabel := "false"
+	if job.Supervisor {
+		supervisorLabel = "true"
+		atomic.AddInt64(&p.supervisorActive, 1)
+	}
+	p.metrics.dispatched.WithLabelValues(supervisorLabel).Inc()
+
+	ctx := context.Background()
+	if !job.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, job.Deadline)
+		defer cancel()
+	}
+
+	job.done <- job.Fn(ctx)
+
+	if job.Supervisor {
+		atomic.AddInt64(&p.supervisorActive, -1)
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// Stop halts the dispatcher and every idle worker immediately, without
+// waiting for any job currently in flight to finish. A worker that's
+// mid-job completes that job in the background and exits on its own once
+// it does; call StopAndWait to block until that's actually happened.
+func (p *PriorityPool) Stop() {
+	p.closer.Do(func() {
+		close(p.stopped)
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	})
+}
+
+// StopAndWait stops the pool like Stop, but blocks until every worker —
+// including ones that were executing a job when Stop was called — has
+// exited, mirroring Pool.StopAndWait.
+func (p *PriorityPool) StopAndWait() {
+	p.Stop()
+	p.wg.Wait()
+}
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testLogger is a no-op log.Logger stub, just enough to satisfy
+// NewPriorityPool/NewDispatcher in tests without depending on a real
+// logging backend.
+type testLogger struct{}
+
+func (testLogger) Info(_ string, _ ...any)  {}
+func (testLogger) Error(_ string, _ ...any) {}
+
+func newTestPriorityPool(t *testing.T, workers int, supervisorFraction float64) *PriorityPool {
+	t.Helper()
+	cfg := &PriorityPoolConfig{
+		Name:               t.Name(),
+		PrometheusPrefix:   "test_" + t.Name(),
+		Workers:            workers,
+		SupervisorFraction: supervisorFraction,
+	}
+	p := NewPriorityPool(cfg, testLogger{})
+	t.Cleanup(p.StopAndWait)
+	return p
+}
+
+func TestPriorityPoolOrdersByPriority(t *testing.T) {
+	p := newTestPriorityPool(t, 1, 1)
+
+	// With a single worker, submit lower-then-higher priority jobs back
+	// to back and confirm the higher-priority one is observed first, even
+	// though it was enqueued second.
+	started := make(chan struct{})
+	blockFirst := make(chan struct{})
+	p.SubmitWithPriority(context.Background(), 0, func(_ context.Context) JobResult {
+		close(started)
+		<-blockFirst
+		return JobResult{Outcome: OutcomeOK}
+	})
+	<-started // ensure the first job has claimed the sole worker before queuing more
+
+	var order []int
+	orderCh := make(chan int, 2)
+	p.SubmitWithPriority(context.Background(), 1, func(_ context.Context) JobResult {
+		orderCh <- 1
+		return JobResult{Outcome: OutcomeOK}
+	})
+	p.SubmitWithPriority(context.Background(), 10, func(_ context.Context) JobResult {
+		orderCh <- 10
+		return JobResult{Outcome: OutcomeOK}
+	})
+	close(blockFirst)
+
+	for i := 0; i < 2; i++ {
+		order = append(order, <-orderCh)
+	}
+	if order[0] != 10 || order[1] != 1 {
+		t.Fatalf("expected higher-priority job (10) to run before lower-priority job (1), got order %v", order)
+	}
+}
+
+func TestPriorityPoolDropsExpiredDeadline(t *testing.T) {
+	p := newTestPriorityPool(t, 1, 1)
+
+	// Occupy the sole worker so the next job is forced to queue.
+	blockFirst := make(chan struct{})
+	started := make(chan struct{})
+	p.SubmitWithPriority(context.Background(), 0, func(_ context.Context) JobResult {
+		close(started)
+		<-blockFirst
+		return JobResult{Outcome: OutcomeOK}
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := p.SubmitWithPriority(ctx, 0, func(_ context.Context) JobResult {
+		return JobResult{Outcome: OutcomeOK}
+	})
+
+	time.Sleep(50 * time.Millisecond) // let the deadline elapse while queued
+	close(blockFirst)
+
+	result := <-done
+	if result.Outcome != OutcomeDeadlineExceeded {
+		t.Fatalf("expected OutcomeDeadlineExceeded for a job whose deadline elapsed while queued, got %v", result.Outcome)
+	}
+}
+
+func TestPriorityPoolSupervisorCapDoesNotStarveLeafJobs(t *testing.T) {
+	// 2 workers, supervisor fraction 0.5 -> supervisor cap of 1.
+	p := newTestPriorityPool(t, 2, 0.5)
+
+	blockSupervisors := make(chan struct{})
+	supervisorsStarted := make(chan struct{}, 2)
+
+	// Queue two supervisor jobs; only one may run at a time under the
+	// cap, so the second must stay queued behind the cap rather than
+	// occupying both workers.
+	for i := 0; i < 2; i++ {
+		p.SubmitWithPriority(context.Background(), 0, func(_ context.Context) JobResult {
+			supervisorsStarted <- struct{}{}
+			<-blockSupervisors
+			return JobResult{Outcome: OutcomeOK}
+		}, WithSupervisor())
+	}
+
+	<-supervisorsStarted // first supervisor job claims its slot
+
+	// A leaf job submitted after the supervisors should still be able to
+	// run on the second worker, rather than waiting behind the capped
+	// second supervisor job.
+	leafDone := p.SubmitWithPriority(context.Background(), 0, func(_ context.Context) JobResult {
+		return JobResult{Outcome: OutcomeOK}
+	})
+
+	select {
+	case result := <-leafDone:
+		if result.Outcome != OutcomeOK {
+			t.Fatalf("expected leaf job to complete OK, got %v", result.Outcome)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("leaf job was starved by supervisor jobs over the supervisor cap")
+	}
+
+	close(blockSupervisors)
+}
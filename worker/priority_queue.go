@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// PriorityJob is a unit of work submitted to a PriorityPool via
+// SubmitWithPriority.
+type PriorityJob struct {
+	// Priority ranks the job against others in the queue; higher values
+	// run first.
+	Priority int
+	// EnqueuedAt is when the job was pushed onto the queue, used as the
+	// tie-breaker between jobs of equal Priority.
+	EnqueuedAt time.Time
+	// Deadline, if non-zero, causes the job to be dropped with
+	// OutcomeDeadlineExceeded if it is still queued once the deadline has
+	// elapsed.
+	Deadline time.Time
+	// Supervisor marks a job as coordinator/orchestration work subject to
+	// the pool's supervisor fraction cap, so it cannot starve leaf jobs.
+	Supervisor bool
+	// Fn is the job body. Its context is derived from Deadline, if set.
+	Fn func(ctx context.Context) JobResult
+
+	done chan JobResult
+}
+
+// jobHeap is a binary heap of *PriorityJob ordered by (priority desc,
+// enqueueTime asc), implementing container/heap.Interface.
+type jobHeap []*PriorityJob
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority > h[j].Priority
+	}
+	return h[i].EnqueuedAt.Before(h[j].EnqueuedAt)
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x any) {
+	*h = append(*h, x.(*PriorityJob))
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return job
+}